@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Finding is a single problem surfaced by a registered analyzer check.
+type Finding struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Message   string
+}
+
+// AnalyzerCheck is implemented by each individual check this server runs. Handler.Run
+// concatenates the findings from every registered check into one RunResponse, so adding
+// a new check doesn't require touching the gRPC plumbing.
+type AnalyzerCheck interface {
+	Name() string
+	Analyze(ctx context.Context, deps *analyzerDeps) ([]Finding, error)
+}
+
+// analyzerDeps bundles what checks need to read cluster/cache state, so adding a check
+// doesn't mean widening Run's signature every time.
+type analyzerDeps struct {
+	clientset kubernetes.Interface
+	cache     *cacheManager
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	autoscalingv2listers "k8s.io/client-go/listers/autoscaling/v2"
+)
+
+// defaultResync is how often the informers reconcile their caches against the API
+// server, independent of watch events. This matches the SharedInformerFactory default
+// used elsewhere in the k8sgpt ecosystem.
+const defaultResync = 10 * time.Minute
+
+// cacheManager backs Run with shared informer caches for Deployments, StatefulSets,
+// HorizontalPodAutoscalers, and PodDisruptionBudgets, so a gRPC invocation reads from
+// memory instead of issuing List calls per namespace per analyzer on every Run.
+//
+// Deployments and StatefulSets still need their full spec (the selector we match
+// against lives there, not in ObjectMeta), so this uses typed informers rather than
+// PartialObjectMetadata watches. A metadata-only watch only pays off for fields that
+// live on ObjectMeta, which selectors don't.
+type cacheManager struct {
+	deploymentLister  appslisters.DeploymentLister
+	statefulSetLister appslisters.StatefulSetLister
+	hpaLister         autoscalingv2listers.HorizontalPodAutoscalerLister
+	pdbs              pdbSource
+}
+
+// newCacheManager builds a shared informer factory for Deployments, StatefulSets,
+// HorizontalPodAutoscalers, and (version-negotiated) PodDisruptionBudgets, starts it,
+// and blocks until the initial list has populated every cache.
+func newCacheManager(ctx context.Context, clientset kubernetes.Interface) (*cacheManager, error) {
+	factory := informers.NewSharedInformerFactory(clientset, defaultResync)
+
+	deployInformer := factory.Apps().V1().Deployments()
+	deployInformer.Informer() // register with factory so Start/WaitForCacheSync picks it up
+	stsInformer := factory.Apps().V1().StatefulSets()
+	stsInformer.Informer() // register with factory so Start/WaitForCacheSync picks it up
+	hpaInformer := factory.Autoscaling().V2().HorizontalPodAutoscalers()
+	hpaInformer.Informer() // register with factory so Start/WaitForCacheSync picks it up
+
+	pdbs, err := newPDBSource(clientset, factory)
+	if err != nil {
+		return nil, err
+	}
+
+	factory.Start(ctx.Done())
+	for informerType, ok := range factory.WaitForCacheSync(ctx.Done()) {
+		if !ok {
+			return nil, fmt.Errorf("cache for %v did not sync", informerType)
+		}
+	}
+
+	return &cacheManager{
+		deploymentLister:  deployInformer.Lister(),
+		statefulSetLister: stsInformer.Lister(),
+		hpaLister:         hpaInformer.Lister(),
+		pdbs:              pdbs,
+	}, nil
+}
+
+func (c *cacheManager) deployments(namespace string) ([]*appsv1.Deployment, error) {
+	return c.deploymentLister.Deployments(namespace).List(labels.Everything())
+}
+
+func (c *cacheManager) statefulSets(namespace string) ([]*appsv1.StatefulSet, error) {
+	return c.statefulSetLister.StatefulSets(namespace).List(labels.Everything())
+}
+
+func (c *cacheManager) hpas(namespace string) ([]*autoscalingv2.HorizontalPodAutoscaler, error) {
+	return c.hpaLister.HorizontalPodAutoscalers(namespace).List(labels.Everything())
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// TestNewCacheManager_PopulatesDeploymentCache guards against the informers being
+// constructed (via the typed accessor) but never registered with the factory: if
+// .Informer() isn't called before Start(), the Deployment informer's Run goroutine
+// never launches and deployments() silently returns nothing forever.
+func TestNewCacheManager_PopulatesDeploymentCache(t *testing.T) {
+	deploy := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+
+	clientset := fake.NewSimpleClientset(deploy)
+	clientset.Fake.Resources = []*metav1.APIResourceList{
+		{GroupVersion: "policy/v1", APIResources: []metav1.APIResource{
+			{Name: "poddisruptionbudgets", Kind: "PodDisruptionBudget"},
+		}},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cache, err := newCacheManager(ctx, clientset)
+	if err != nil {
+		t.Fatalf("newCacheManager returned error: %v", err)
+	}
+
+	deployments, err := cache.deployments("default")
+	if err != nil {
+		t.Fatalf("deployments() returned error: %v", err)
+	}
+	if len(deployments) != 1 || deployments[0].Name != "web" {
+		t.Fatalf("expected the seeded Deployment to come back from the cache, got %v", deployments)
+	}
+}
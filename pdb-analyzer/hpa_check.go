@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// hpaCheck flags Deployments that have no HorizontalPodAutoscaler targeting them,
+// modeled on pdbCheck (pdb_check.go) but for autoscaling coverage instead of disruption
+// coverage.
+type hpaCheck struct{}
+
+func (c *hpaCheck) Name() string {
+	return "missing-hpa"
+}
+
+func (c *hpaCheck) Analyze(ctx context.Context, deps *analyzerDeps) ([]Finding, error) {
+	namespaceList, err := deps.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+
+	for _, ns := range namespaceList.Items {
+		namespace := ns.Name
+
+		deployments, err := deps.cache.deployments(namespace)
+		if err != nil {
+			continue
+		}
+
+		hpas, err := deps.cache.hpas(namespace)
+		if err != nil {
+			continue
+		}
+
+		targeted := make(map[string]struct{}, len(hpas))
+		for _, hpa := range hpas {
+			if hpa.Spec.ScaleTargetRef.Kind == "Deployment" {
+				targeted[hpa.Spec.ScaleTargetRef.Name] = struct{}{}
+			}
+		}
+
+		for _, deploy := range deployments {
+			if _, ok := targeted[deploy.Name]; ok {
+				continue
+			}
+			findings = append(findings, Finding{
+				Kind:      "Deployment",
+				Namespace: namespace,
+				Name:      deploy.Name,
+				Message:   "no HorizontalPodAutoscaler targets this Deployment; consider adding one or documenting that it intentionally runs a fixed replica count",
+			})
+		}
+	}
+
+	return findings, nil
+}
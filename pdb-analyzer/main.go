@@ -2,19 +2,20 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"flag"
 	"fmt"
 	"net"
 	"net/http"
-	"sort"
+	"os"
 	"strings"
 
 	rpc "buf.build/gen/go/k8sgpt-ai/k8sgpt/grpc/go/schema/v1/schemav1grpc"
 	v1 "buf.build/gen/go/k8sgpt-ai/k8sgpt/protocolbuffers/go/schema/v1"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
-	
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
@@ -22,6 +23,17 @@ import (
 // Handler implements the analyzer interface
 type Handler struct {
 	rpc.CustomAnalyzerServiceServer
+
+	// Clientset is used for cluster operations the informer caches don't cover, such
+	// as listing namespaces and server-side applying remediated PDBs.
+	Clientset kubernetes.Interface
+	// Cache backs Deployment, StatefulSet, HPA, and PDB reads with shared informers
+	// instead of a List call per namespace per check.
+	Cache *cacheManager
+
+	// Checks is the registry of analyzer checks Run fans out to. New checks plug in
+	// here without Run itself needing to change.
+	Checks []AnalyzerCheck
 }
 
 // Analyzer struct holds the handler
@@ -29,179 +41,120 @@ type Analyzer struct {
 	Handler *Handler
 }
 
-// NewHandler creates a new analyzer handler
-func NewHandler() *Handler {
-	return &Handler{}
-}
+// NewHandler creates a new analyzer handler backed by clientset/cache, with the pdbCheck
+// registered first (configured with the given remediation mode, default availability
+// policy, and per-namespace overrides for generated PDBs) followed by any additional
+// checks.
+func NewHandler(clientset kubernetes.Interface, cache *cacheManager, mode RemediationMode, defaultMinAvailable, defaultMaxUnavailable string, namespaceOverrides map[string]namespacePolicy, extraChecks ...AnalyzerCheck) *Handler {
+	checks := append([]AnalyzerCheck{
+		&pdbCheck{
+			RemediationMode:       mode,
+			DefaultMinAvailable:   defaultMinAvailable,
+			DefaultMaxUnavailable: defaultMaxUnavailable,
+			NamespaceOverrides:    namespaceOverrides,
+		},
+	}, extraChecks...)
 
-// Helper function to split a workload string like "Deployment 'namespace/name'" into namespace and workload parts
-func splitNamespaceWorkload(input string) []string {
-	// Extract the 'namespace/name' part from the string
-	parts := strings.Split(input, "'")
-	if len(parts) < 2 {
-		return []string{}
-	}
-	
-	// Split namespace and workload name
-	namespaceParts := strings.Split(parts[1], "/")
-	if len(namespaceParts) != 2 {
-		return []string{}
+	return &Handler{
+		Clientset: clientset,
+		Cache:     cache,
+		Checks:    checks,
 	}
-	
-	// Return namespace, workload type, and name separately
-	workloadType := strings.Split(input, " ")[0] // Get "Deployment" or "StatefulSet"
-	return []string{namespaceParts[0], workloadType, namespaceParts[1]}
 }
 
-// Run is the implementation of the analyzer interface
+// Run is the implementation of the analyzer interface. It fans out to every registered
+// check and concatenates their findings into a single RunResponse.
 func (a *Handler) Run(ctx context.Context, req *v1.RunRequest) (*v1.RunResponse, error) {
-	config, err := rest.InClusterConfig()
-	if err != nil {
-		return nil, err
-	}
-
-	clientset, err := kubernetes.NewForConfig(config)
-	if err != nil {
-		return nil, err
-	}
-
-	missingPDBs := []string{}
-
-	// Get all namespaces
-	namespaceList, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, err
-	}
+	analyzerRunsTotal.WithLabelValues("pdb-analyzer").Inc()
 
-	for _, ns := range namespaceList.Items {
-		namespace := ns.Name
+	deps := &analyzerDeps{clientset: a.Clientset, cache: a.Cache}
 
-		// Get deployments
-		deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
-		if err != nil {
-			continue // skip namespace if error occurs
-		}
+	var output strings.Builder
+	total := 0
 
-		// Get statefulsets
-		statefulsets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	for _, check := range a.Checks {
+		findings, err := check.Analyze(ctx, deps)
 		if err != nil {
+			analyzerErrorsTotal.WithLabelValues(check.Name()).Inc()
+			output.WriteString(fmt.Sprintf("=== %s ===\nerror running check: %v\n\n", check.Name(), err))
 			continue
 		}
-
-		// Get pdbs
-		pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
-		if err != nil {
+		if len(findings) == 0 {
 			continue
 		}
 
-		// Index PDBs by selector
-		pdbMap := make(map[string]struct{})
-		for _, pdb := range pdbs.Items {
-			selector := pdb.Spec.Selector.String()
-			pdbMap[selector] = struct{}{}
-		}
-
-		// Check deployments
-		for _, deploy := range deployments.Items {
-			selector := metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: deploy.Spec.Selector.MatchLabels})
-			if _, exists := pdbMap[selector]; !exists {
-				missingPDBs = append(missingPDBs, fmt.Sprintf("Deployment '%s/%s'", namespace, deploy.Name))
-			}
-		}
-
-		// Check statefulsets
-		for _, sts := range statefulsets.Items {
-			selector := metav1.FormatLabelSelector(&metav1.LabelSelector{MatchLabels: sts.Spec.Selector.MatchLabels})
-			if _, exists := pdbMap[selector]; !exists {
-				missingPDBs = append(missingPDBs, fmt.Sprintf("StatefulSet '%s/%s'", namespace, sts.Name))
-			}
+		total += len(findings)
+		output.WriteString(fmt.Sprintf("=== %s (%d finding(s)) ===\n", check.Name(), len(findings)))
+		for _, f := range findings {
+			output.WriteString(fmt.Sprintf("  - %s '%s/%s': %s\n", f.Kind, f.Namespace, f.Name, f.Message))
 		}
+		output.WriteString("\n")
 	}
 
-	if len(missingPDBs) == 0 {
+	if total == 0 {
 		return &v1.RunResponse{
 			Result: &v1.Result{
 				Name:    "pdb-analyzer",
-				Details: "All Deployments and StatefulSets across all namespaces have matching PDBs.",
+				Details: "No issues detected by any registered analyzer check.",
 			},
 		}, nil
 	}
-	
-	// Group workloads by namespace for better organization
-	namespaceMap := make(map[string][]string)
-	for _, workload := range missingPDBs {
-		parts := splitNamespaceWorkload(workload)
-		if len(parts) == 3 {
-			namespace := parts[0]
-			kind := parts[1]
-			name := parts[2]
-			
-			// Store workload info as "Kind Name" format
-			namespaceMap[namespace] = append(namespaceMap[namespace], kind + " " + name)
-		}
-	}
-	
-	// Format the output in a human-readable way
-	var formattedOutput strings.Builder
-	formattedOutput.WriteString("Missing PodDisruptionBudgets detected for the following workloads:\n\n")
-	
-	// Get sorted list of namespaces for consistent output
-	var namespaceNames []string
-	for ns := range namespaceMap {
-		namespaceNames = append(namespaceNames, ns)
-	}
-	sort.Strings(namespaceNames)
-	
-	// Build the human-readable output
-	for _, ns := range namespaceNames {
-		formattedOutput.WriteString(fmt.Sprintf("Namespace: %s\n", ns))
-		
-		// Add each workload to the human-readable output
-		for _, workload := range namespaceMap[ns] {
-			formattedOutput.WriteString(fmt.Sprintf("  - %s\n", workload))
-		}
-		formattedOutput.WriteString("\n")
-	}
-	
-	// Add the recommendation
-	formattedOutput.WriteString("\n=== RECOMMENDATION ===\n")
-	formattedOutput.WriteString("Create PodDisruptionBudgets for these workloads to ensure high availability during voluntary disruptions.\n")
-	formattedOutput.WriteString("\n=== HOW TO FIX ===\n")
-	formattedOutput.WriteString("For each workload, create a PDB that matches the workload's selector.\n")
-	formattedOutput.WriteString("Example for Deployment 'app' in namespace 'default':\n\n")
-	formattedOutput.WriteString("```yaml\n")
-	formattedOutput.WriteString("apiVersion: policy/v1\n")
-	formattedOutput.WriteString("kind: PodDisruptionBudget\n")
-	formattedOutput.WriteString("metadata:\n")
-	formattedOutput.WriteString("  name: app-pdb\n")
-	formattedOutput.WriteString("  namespace: default\n")
-	formattedOutput.WriteString("spec:\n")
-	formattedOutput.WriteString("  minAvailable: 1  # or use maxUnavailable\n")
-	formattedOutput.WriteString("  selector:\n")
-	formattedOutput.WriteString("    matchLabels:\n")
-	formattedOutput.WriteString("      app: app-name  # must match your workload's selector\n")
-	formattedOutput.WriteString("```\n\n")
-	
-	// Also include a summary of missing PDBs by namespace
-	formattedOutput.WriteString("=== SUMMARY ===\n")
-	formattedOutput.WriteString("Missing PodDisruptionBudgets by namespace:\n")
-	for _, ns := range namespaceNames {
-		formattedOutput.WriteString(fmt.Sprintf("  - %s: %d workloads\n", ns, len(namespaceMap[ns])))
-	}
 
 	return &v1.RunResponse{
 		Result: &v1.Result{
 			Name:    "pdb-analyzer",
-			Details: "Missing PodDisruptionBudgets detected for some workloads.",
+			Details: fmt.Sprintf("%d issue(s) detected across %d analyzer check(s).", total, len(a.Checks)),
 			Error: []*v1.ErrorDetail{{
-				Text: formattedOutput.String(),
+				Text: output.String(),
 			}},
 		},
 	}, nil
 }
 
+// envOrDefault returns the named environment variable's value, or def if it is unset.
+func envOrDefault(name, def string) string {
+	if v := os.Getenv(name); v != "" {
+		return v
+	}
+	return def
+}
+
+// parseNamespaceOverrides decodes the --namespace-overrides/PDB_NAMESPACE_OVERRIDES
+// JSON object, keyed by namespace name, into the map pdbCheck consults before falling
+// back to its handler-wide default availability policy. An empty string is valid and
+// means no overrides are configured.
+func parseNamespaceOverrides(raw string) (map[string]namespacePolicy, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var overrides map[string]namespacePolicy
+	if err := json.Unmarshal([]byte(raw), &overrides); err != nil {
+		return nil, fmt.Errorf("parsing namespace overrides: %w", err)
+	}
+	return overrides, nil
+}
+
 func main() {
-	var err error
+	remediationMode := flag.String("remediation-mode", envOrDefault("REMEDIATION_MODE", string(RemediationOff)),
+		"remediation mode: off, dry-run, or apply (env REMEDIATION_MODE)")
+	defaultMinAvailable := flag.String("default-min-available", envOrDefault("PDB_DEFAULT_MIN_AVAILABLE", "1"),
+		"default spec.minAvailable for generated PDBs, e.g. 1 or 50% (env PDB_DEFAULT_MIN_AVAILABLE)")
+	defaultMaxUnavailable := flag.String("default-max-unavailable", envOrDefault("PDB_DEFAULT_MAX_UNAVAILABLE", ""),
+		"default spec.maxUnavailable for generated PDBs; overrides default-min-available when set (env PDB_DEFAULT_MAX_UNAVAILABLE)")
+	namespaceOverridesJSON := flag.String("namespace-overrides", envOrDefault("PDB_NAMESPACE_OVERRIDES", ""),
+		`per-namespace availability policy overrides as JSON, e.g. {"kube-system":{"minAvailable":"2"}} (env PDB_NAMESPACE_OVERRIDES)`)
+	flag.Parse()
+
+	namespaceOverrides, err := parseNamespaceOverrides(*namespaceOverridesJSON)
+	if err != nil {
+		panic(err)
+	}
+
+	mode, err := parseRemediationMode(*remediationMode)
+	if err != nil {
+		panic(err)
+	}
+
 	address := fmt.Sprintf(":%s", "8085")
 	lis, err := net.Listen("tcp", address)
 	if err != nil {
@@ -209,15 +162,37 @@ func main() {
 	}
 	grpcServer := grpc.NewServer()
 	reflection.Register(grpcServer)
-	
-	// Initialize our analyzer
+
+	// Metrics are served on their own port so Prometheus scraping is independent
+	// of the gRPC analyzer traffic, matching the k8sgpt core analyzer pattern.
+	serveMetrics(":8086")
+
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		panic(err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	// Build the informer caches once at startup; Run reads from them on every
+	// invocation instead of listing Deployments/StatefulSets/PDBs per namespace.
+	cache, err := newCacheManager(context.Background(), clientset)
+	if err != nil {
+		panic(err)
+	}
+
+	// Initialize our analyzer. The PDB check is registered by NewHandler itself; the
+	// HPA check is the second analyzer sharing this gRPC server.
 	aa := Analyzer{
-		Handler: NewHandler(),
+		Handler: NewHandler(clientset, cache, mode, *defaultMinAvailable, *defaultMaxUnavailable, namespaceOverrides, &hpaCheck{}),
 	}
 
 	// Register the analyzer service
 	rpc.RegisterCustomAnalyzerServiceServer(grpcServer, aa.Handler)
-	
+
 	fmt.Println("Starting PDB Analyzer server on port 8085!")
 	if err := grpcServer.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		panic(err)
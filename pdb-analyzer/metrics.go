@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Metrics mirrors the naming scheme k8sgpt core uses in pkg/analyzer/analyzer.go:
+// a "_total" counter for invocations/errors and a gauge for the thing being tracked.
+var (
+	analyzerRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_pdb_analyzer_runs_total",
+		Help: "Total number of times the pdb-analyzer Run method has been invoked.",
+	}, []string{"analyzer"})
+
+	analyzerErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "k8sgpt_pdb_analyzer_errors_total",
+		Help: "Total number of errors encountered while running the pdb-analyzer.",
+	}, []string{"analyzer"})
+
+	workloadsMissingPDB = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "k8sgpt_pdb_analyzer_workloads_missing_pdb",
+		Help: "Number of workloads missing a matching PodDisruptionBudget, labeled by namespace and workload kind.",
+	}, []string{"namespace", "kind"})
+)
+
+// serveMetrics starts a Prometheus metrics endpoint on its own port, separate from
+// the gRPC analyzer port, so scraping never competes with analyzer traffic.
+func serveMetrics(address string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+
+	go func() {
+		if err := http.ListenAndServe(address, mux); err != nil {
+			fmt.Printf("metrics server stopped: %v\n", err)
+		}
+	}()
+}
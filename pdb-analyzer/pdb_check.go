@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// pdbCheck is the original analyzer: it finds Deployments and StatefulSets with no
+// covering PodDisruptionBudget, and optionally remediates what it finds.
+type pdbCheck struct {
+	// RemediationMode controls whether Analyze only reports missing PDBs (off), also
+	// returns generated manifests (dry-run), or applies them to the cluster (apply).
+	RemediationMode RemediationMode
+	// DefaultMinAvailable is used to populate spec.minAvailable on generated PDBs
+	// when DefaultMaxUnavailable is not set.
+	DefaultMinAvailable string
+	// DefaultMaxUnavailable, when set, is used to populate spec.maxUnavailable on
+	// generated PDBs instead of DefaultMinAvailable.
+	DefaultMaxUnavailable string
+	// NamespaceOverrides replaces the default availability policy for generated PDBs
+	// in specific namespaces, keyed by namespace name.
+	NamespaceOverrides map[string]namespacePolicy
+}
+
+func (c *pdbCheck) Name() string {
+	return "pdb-analyzer"
+}
+
+func (c *pdbCheck) Analyze(ctx context.Context, deps *analyzerDeps) ([]Finding, error) {
+	namespaceList, err := deps.clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var findings []Finding
+
+	for _, ns := range namespaceList.Items {
+		namespace := ns.Name
+
+		deployments, err := deps.cache.deployments(namespace)
+		if err != nil {
+			continue // skip namespace if error occurs
+		}
+
+		statefulsets, err := deps.cache.statefulSets(namespace)
+		if err != nil {
+			continue
+		}
+
+		// Get pdbs, via whichever API version the cluster serves
+		selectors, err := deps.cache.pdbs.ListSelectors(namespace)
+		if err != nil {
+			continue
+		}
+
+		deploymentsMissing := 0
+		for _, deploy := range deployments {
+			covered, err := workloadCoveredByPDB(selectors, deploy.Spec.Template.Labels)
+			if err != nil {
+				analyzerErrorsTotal.WithLabelValues(c.Name()).Inc()
+				continue
+			}
+			if !covered {
+				w := missingWorkload{Namespace: namespace, Kind: "Deployment", Name: deploy.Name, Selector: deploy.Spec.Selector}
+				findings = append(findings, c.remediate(ctx, deps, w))
+				deploymentsMissing++
+			}
+		}
+		workloadsMissingPDB.WithLabelValues(namespace, "Deployment").Set(float64(deploymentsMissing))
+
+		statefulsetsMissing := 0
+		for _, sts := range statefulsets {
+			covered, err := workloadCoveredByPDB(selectors, sts.Spec.Template.Labels)
+			if err != nil {
+				analyzerErrorsTotal.WithLabelValues(c.Name()).Inc()
+				continue
+			}
+			if !covered {
+				w := missingWorkload{Namespace: namespace, Kind: "StatefulSet", Name: sts.Name, Selector: sts.Spec.Selector}
+				findings = append(findings, c.remediate(ctx, deps, w))
+				statefulsetsMissing++
+			}
+		}
+		workloadsMissingPDB.WithLabelValues(namespace, "StatefulSet").Set(float64(statefulsetsMissing))
+	}
+
+	return findings, nil
+}
+
+// remediate turns a missing workload into a Finding, generating (and, depending on
+// RemediationMode, rendering or applying) a PDB manifest for it along the way.
+func (c *pdbCheck) remediate(ctx context.Context, deps *analyzerDeps, w missingWorkload) Finding {
+	finding := Finding{
+		Kind:      w.Kind,
+		Namespace: w.Namespace,
+		Name:      w.Name,
+		Message:   "no PodDisruptionBudget covers this workload's pod template labels",
+	}
+
+	if c.RemediationMode == RemediationOff {
+		return finding
+	}
+
+	minAvailable, maxUnavailable := resolvePolicy(c.NamespaceOverrides, w.Namespace, c.DefaultMinAvailable, c.DefaultMaxUnavailable)
+	pdb := buildPDBManifest(w, minAvailable, maxUnavailable)
+
+	switch c.RemediationMode {
+	case RemediationDryRun:
+		manifest, err := renderPDBManifestYAML(pdb)
+		if err != nil {
+			analyzerErrorsTotal.WithLabelValues(c.Name()).Inc()
+			finding.Message += fmt.Sprintf("; failed to render a suggested manifest: %v", err)
+			return finding
+		}
+		finding.Message += fmt.Sprintf("; suggested manifest:\n```yaml\n%s```", manifest)
+	case RemediationApply:
+		if err := deps.cache.pdbs.Apply(ctx, pdb); err != nil {
+			analyzerErrorsTotal.WithLabelValues(c.Name()).Inc()
+			finding.Message += fmt.Sprintf("; failed to apply %s: %v", pdb.Name, err)
+			return finding
+		}
+		finding.Message += fmt.Sprintf("; applied %s", pdb.Name)
+	}
+
+	return finding
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	metav1apply "k8s.io/client-go/applyconfigurations/meta/v1"
+	applypolicyv1 "k8s.io/client-go/applyconfigurations/policy/v1"
+	applypolicyv1beta1 "k8s.io/client-go/applyconfigurations/policy/v1beta1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	policyv1listers "k8s.io/client-go/listers/policy/v1"
+	policyv1beta1listers "k8s.io/client-go/listers/policy/v1beta1"
+)
+
+// pdbFieldManager is the field manager used for every server-side apply this analyzer
+// issues, so re-runs converge instead of conflicting with manually created PDBs.
+const pdbFieldManager = "pdb-analyzer"
+
+// pdbSource abstracts PodDisruptionBudget reads and writes behind a single
+// version-agnostic interface so the rest of the analyzer doesn't need to know whether
+// the cluster serves the stable policy/v1 API or the deprecated policy/v1beta1 API.
+// Reads are backed by an informer cache; Apply issues a live server-side apply call
+// against whichever version was negotiated.
+type pdbSource interface {
+	// ListSelectors returns the selector of every PodDisruptionBudget in namespace.
+	ListSelectors(namespace string) ([]*metav1.LabelSelector, error)
+	// Apply server-side applies a generated PDB manifest, translating it to whichever
+	// PDB API version this source negotiated.
+	Apply(ctx context.Context, pdb *policyv1.PodDisruptionBudget) error
+}
+
+// labelSelectorApplyConfig converts a generated PDB's selector into its apply-config
+// form, carrying matchExpressions across as faithfully as matchLabels so a workload
+// selected purely by matchExpressions doesn't get server-side applied with an
+// effectively empty selector.
+func labelSelectorApplyConfig(sel *metav1.LabelSelector) *metav1apply.LabelSelectorApplyConfiguration {
+	applyConfig := metav1apply.LabelSelector()
+	if sel == nil {
+		return applyConfig
+	}
+	if sel.MatchLabels != nil {
+		applyConfig = applyConfig.WithMatchLabels(sel.MatchLabels)
+	}
+	for _, expr := range sel.MatchExpressions {
+		applyConfig = applyConfig.WithMatchExpressions(metav1apply.LabelSelectorRequirement().
+			WithKey(expr.Key).
+			WithOperator(expr.Operator).
+			WithValues(expr.Values...))
+	}
+	return applyConfig
+}
+
+// cachedPolicyV1Source lists and applies PDBs via the policy/v1 API.
+type cachedPolicyV1Source struct {
+	clientset kubernetes.Interface
+	lister    policyv1listers.PodDisruptionBudgetLister
+}
+
+func (s *cachedPolicyV1Source) ListSelectors(namespace string) ([]*metav1.LabelSelector, error) {
+	pdbs, err := s.lister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	selectors := make([]*metav1.LabelSelector, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		selectors = append(selectors, pdb.Spec.Selector)
+	}
+	return selectors, nil
+}
+
+func (s *cachedPolicyV1Source) Apply(ctx context.Context, pdb *policyv1.PodDisruptionBudget) error {
+	spec := applypolicyv1.PodDisruptionBudgetSpec().
+		WithSelector(labelSelectorApplyConfig(pdb.Spec.Selector))
+	if pdb.Spec.MinAvailable != nil {
+		spec = spec.WithMinAvailable(*pdb.Spec.MinAvailable)
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		spec = spec.WithMaxUnavailable(*pdb.Spec.MaxUnavailable)
+	}
+
+	applyConfig := applypolicyv1.PodDisruptionBudget(pdb.Name, pdb.Namespace).WithSpec(spec)
+
+	_, err := s.clientset.PolicyV1().PodDisruptionBudgets(pdb.Namespace).Apply(ctx, applyConfig, metav1.ApplyOptions{
+		FieldManager: pdbFieldManager,
+		Force:        true,
+	})
+	return err
+}
+
+// cachedPolicyV1beta1Source lists and applies PDBs via the deprecated policy/v1beta1
+// API, for older clusters that don't yet serve policy/v1.
+type cachedPolicyV1beta1Source struct {
+	clientset kubernetes.Interface
+	lister    policyv1beta1listers.PodDisruptionBudgetLister
+}
+
+func (s *cachedPolicyV1beta1Source) ListSelectors(namespace string) ([]*metav1.LabelSelector, error) {
+	pdbs, err := s.lister.PodDisruptionBudgets(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	selectors := make([]*metav1.LabelSelector, 0, len(pdbs))
+	for _, pdb := range pdbs {
+		selectors = append(selectors, pdb.Spec.Selector)
+	}
+	return selectors, nil
+}
+
+func (s *cachedPolicyV1beta1Source) Apply(ctx context.Context, pdb *policyv1.PodDisruptionBudget) error {
+	spec := applypolicyv1beta1.PodDisruptionBudgetSpec().
+		WithSelector(labelSelectorApplyConfig(pdb.Spec.Selector))
+	if pdb.Spec.MinAvailable != nil {
+		spec = spec.WithMinAvailable(*pdb.Spec.MinAvailable)
+	}
+	if pdb.Spec.MaxUnavailable != nil {
+		spec = spec.WithMaxUnavailable(*pdb.Spec.MaxUnavailable)
+	}
+
+	applyConfig := applypolicyv1beta1.PodDisruptionBudget(pdb.Name, pdb.Namespace).WithSpec(spec)
+
+	_, err := s.clientset.PolicyV1beta1().PodDisruptionBudgets(pdb.Namespace).Apply(ctx, applyConfig, metav1.ApplyOptions{
+		FieldManager: pdbFieldManager,
+		Force:        true,
+	})
+	return err
+}
+
+// newPDBSource negotiates which PDB API version the cluster serves, preferring the
+// stable policy/v1 and falling back to policy/v1beta1 for older clusters where v1 is
+// not yet available, and wires the winning version's informer into factory so it starts
+// and syncs alongside the Deployment/StatefulSet informers. Both reads (ListSelectors)
+// and writes (Apply) go through whichever version was negotiated here.
+func newPDBSource(clientset kubernetes.Interface, factory informers.SharedInformerFactory) (pdbSource, error) {
+	if _, err := clientset.Discovery().ServerResourcesForGroupVersion("policy/v1"); err == nil {
+		informer := factory.Policy().V1().PodDisruptionBudgets()
+		informer.Informer() // register with factory so Start/WaitForCacheSync picks it up
+		return &cachedPolicyV1Source{clientset: clientset, lister: informer.Lister()}, nil
+	}
+
+	if _, err := clientset.Discovery().ServerResourcesForGroupVersion("policy/v1beta1"); err == nil {
+		informer := factory.Policy().V1beta1().PodDisruptionBudgets()
+		informer.Informer()
+		return &cachedPolicyV1beta1Source{clientset: clientset, lister: informer.Lister()}, nil
+	}
+
+	return nil, fmt.Errorf("cluster serves neither policy/v1 nor policy/v1beta1 PodDisruptionBudgets")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/yaml"
+)
+
+// RemediationMode controls what, if anything, the analyzer does about a missing PDB
+// beyond reporting it.
+type RemediationMode string
+
+const (
+	// RemediationOff only reports missing PDBs, the original behavior.
+	RemediationOff RemediationMode = "off"
+	// RemediationDryRun generates the PDB manifest and returns it as YAML in the response.
+	RemediationDryRun RemediationMode = "dry-run"
+	// RemediationApply generates the PDB manifest and server-side applies it to the cluster.
+	RemediationApply RemediationMode = "apply"
+)
+
+// missingWorkload captures everything needed to build a remediating PDB manifest for a
+// Deployment or StatefulSet that has no matching PodDisruptionBudget. Selector is the
+// workload's own full selector (matchLabels and matchExpressions alike) so a canary or
+// hash-based workload whose selector relies solely on matchExpressions doesn't get
+// remediated into a PDB that, lacking any selector of its own, would cover every pod in
+// the namespace instead of just that workload's.
+type missingWorkload struct {
+	Namespace string
+	Kind      string
+	Name      string
+	Selector  *metav1.LabelSelector
+}
+
+// namespacePolicy overrides the default availability policy for generated PDBs in one
+// namespace. An override replaces both fields of the handler-wide default together; a
+// field left empty is not filled in from the default.
+type namespacePolicy struct {
+	MinAvailable   string `json:"minAvailable,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
+}
+
+// parseRemediationMode validates raw against the known RemediationMode values so a typo
+// in the --remediation-mode flag/REMEDIATION_MODE env var fails fast at startup instead
+// of silently falling through every switch on RemediationMode as RemediationOff.
+func parseRemediationMode(raw string) (RemediationMode, error) {
+	mode := RemediationMode(raw)
+	switch mode {
+	case RemediationOff, RemediationDryRun, RemediationApply:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid remediation mode %q: must be one of %q, %q, %q", raw, RemediationOff, RemediationDryRun, RemediationApply)
+	}
+}
+
+// resolvePolicy returns the minAvailable/maxUnavailable pair to use for a workload in
+// namespace, preferring a per-namespace override over the handler-wide default.
+func resolvePolicy(overrides map[string]namespacePolicy, namespace, defaultMinAvailable, defaultMaxUnavailable string) (minAvailable, maxUnavailable string) {
+	if override, ok := overrides[namespace]; ok {
+		return override.MinAvailable, override.MaxUnavailable
+	}
+	return defaultMinAvailable, defaultMaxUnavailable
+}
+
+// buildPDBManifest generates a policy/v1 PodDisruptionBudget for a missing workload, using
+// the workload's own selector (matchLabels and matchExpressions alike) and the given
+// availability policy (see resolvePolicy). maxUnavailable takes precedence over
+// minAvailable when both are set.
+func buildPDBManifest(w missingWorkload, minAvailable, maxUnavailable string) *policyv1.PodDisruptionBudget {
+	pdb := &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-pdb", w.Name),
+			Namespace: w.Namespace,
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			Selector: w.Selector,
+		},
+	}
+
+	if maxUnavailable != "" {
+		v := intstr.Parse(maxUnavailable)
+		pdb.Spec.MaxUnavailable = &v
+	} else {
+		v := intstr.Parse(minAvailable)
+		pdb.Spec.MinAvailable = &v
+	}
+
+	return pdb
+}
+
+// renderPDBManifestYAML renders a generated PDB manifest as YAML for the dry-run response.
+func renderPDBManifestYAML(pdb *policyv1.PodDisruptionBudget) (string, error) {
+	out, err := yaml.Marshal(pdb)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolvePolicy_NamespaceOverrideTakesPrecedence(t *testing.T) {
+	overrides := map[string]namespacePolicy{
+		"kube-system": {MinAvailable: "2"},
+	}
+
+	min, max := resolvePolicy(overrides, "kube-system", "1", "")
+	if min != "2" || max != "" {
+		t.Fatalf("expected namespace override (2, \"\"), got (%q, %q)", min, max)
+	}
+}
+
+func TestResolvePolicy_FallsBackToDefaultWhenNoOverride(t *testing.T) {
+	overrides := map[string]namespacePolicy{
+		"kube-system": {MinAvailable: "2"},
+	}
+
+	min, max := resolvePolicy(overrides, "default", "1", "")
+	if min != "1" || max != "" {
+		t.Fatalf("expected handler default (1, \"\"), got (%q, %q)", min, max)
+	}
+}
+
+func TestParseRemediationMode_ValidValues(t *testing.T) {
+	for _, raw := range []string{"off", "dry-run", "apply"} {
+		mode, err := parseRemediationMode(raw)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", raw, err)
+		}
+		if string(mode) != raw {
+			t.Fatalf("expected mode %q, got %q", raw, mode)
+		}
+	}
+}
+
+func TestParseRemediationMode_RejectsUnknownValue(t *testing.T) {
+	if _, err := parseRemediationMode("dryrun"); err == nil {
+		t.Fatal("expected an error for an unrecognized remediation mode")
+	}
+}
+
+func TestBuildPDBManifest_PropagatesMatchExpressions(t *testing.T) {
+	w := missingWorkload{
+		Namespace: "default",
+		Kind:      "Deployment",
+		Name:      "canary",
+		Selector: &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "pod-template-hash", Operator: metav1.LabelSelectorOpIn, Values: []string{"abc123"}},
+			},
+		},
+	}
+
+	pdb := buildPDBManifest(w, "1", "")
+
+	if pdb.Spec.Selector == nil || len(pdb.Spec.Selector.MatchExpressions) != 1 {
+		t.Fatalf("expected the workload's matchExpressions to carry over to the generated PDB, got selector: %+v", pdb.Spec.Selector)
+	}
+	if pdb.Spec.Selector.MatchExpressions[0].Key != "pod-template-hash" {
+		t.Fatalf("unexpected matchExpressions on generated PDB: %+v", pdb.Spec.Selector.MatchExpressions)
+	}
+}
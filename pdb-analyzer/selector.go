@@ -0,0 +1,35 @@
+package main
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// workloadCoveredByPDB reports whether at least one of pdbSelectors matches podLabels,
+// using true label-selector semantics (so matchExpressions, not just matchLabels, are
+// honored). Per Kubernetes semantics an empty-but-non-nil LabelSelector matches every
+// set of labels, so a PDB with an empty selector covers every workload in its namespace;
+// a nil selector is skipped and covers nothing.
+// A selector that fails to convert is skipped rather than aborting the whole check, so
+// one malformed PDB in a namespace can't mask coverage from the rest; its conversion
+// error is still returned (once none of the selectors match) so callers can count it.
+func workloadCoveredByPDB(pdbSelectors []*metav1.LabelSelector, podLabels map[string]string) (bool, error) {
+	set := labels.Set(podLabels)
+	var firstErr error
+	for _, sel := range pdbSelectors {
+		if sel == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		if selector.Matches(set) {
+			return true, nil
+		}
+	}
+	return false, firstErr
+}
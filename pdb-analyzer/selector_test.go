@@ -0,0 +1,120 @@
+package main
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWorkloadCoveredByPDB_MatchLabels(t *testing.T) {
+	podLabels := map[string]string{"app": "web", "tier": "frontend"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{MatchLabels: map[string]string{"app": "web"}},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !covered {
+		t.Fatal("expected workload to be covered by matching matchLabels selector")
+	}
+}
+
+func TestWorkloadCoveredByPDB_MatchExpressions(t *testing.T) {
+	podLabels := map[string]string{"app": "web", "tier": "frontend"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "tier", Operator: metav1.LabelSelectorOpIn, Values: []string{"frontend", "backend"}},
+			},
+		},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !covered {
+		t.Fatal("expected workload to be covered by matching matchExpressions selector")
+	}
+}
+
+func TestWorkloadCoveredByPDB_EmptySelectorMatchesEverything(t *testing.T) {
+	podLabels := map[string]string{"app": "anything"}
+	pdbSelectors := []*metav1.LabelSelector{{}}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !covered {
+		t.Fatal("expected an empty selector to match every workload")
+	}
+}
+
+func TestWorkloadCoveredByPDB_MultiPDBOverlapRequiresOnlyOneMatch(t *testing.T) {
+	podLabels := map[string]string{"app": "web"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{MatchLabels: map[string]string{"app": "db"}},
+		{MatchLabels: map[string]string{"app": "web"}},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !covered {
+		t.Fatal("expected workload to be covered when any one of several PDB selectors matches")
+	}
+}
+
+func TestWorkloadCoveredByPDB_NoMatch(t *testing.T) {
+	podLabels := map[string]string{"app": "web"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{MatchLabels: map[string]string{"app": "db"}},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if covered {
+		t.Fatal("expected workload not to be covered when no PDB selector matches")
+	}
+}
+
+func TestWorkloadCoveredByPDB_MalformedSelectorSkippedNotFatal(t *testing.T) {
+	podLabels := map[string]string{"app": "web"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: "NotAnOperator", Values: []string{"web"}},
+		}},
+		{MatchLabels: map[string]string{"app": "web"}},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err != nil {
+		t.Fatalf("expected the malformed selector's error to be swallowed once a later selector matches, got: %v", err)
+	}
+	if !covered {
+		t.Fatal("expected a later valid PDB selector to still cover the workload despite an earlier malformed one")
+	}
+}
+
+func TestWorkloadCoveredByPDB_MalformedSelectorReportedWhenNothingMatches(t *testing.T) {
+	podLabels := map[string]string{"app": "web"}
+	pdbSelectors := []*metav1.LabelSelector{
+		{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "app", Operator: "NotAnOperator", Values: []string{"web"}},
+		}},
+	}
+
+	covered, err := workloadCoveredByPDB(pdbSelectors, podLabels)
+	if err == nil {
+		t.Fatal("expected the malformed selector's conversion error to be returned when no selector matches")
+	}
+	if covered {
+		t.Fatal("expected workload not to be covered when the only PDB selector is malformed")
+	}
+}